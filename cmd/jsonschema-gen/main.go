@@ -0,0 +1,54 @@
+// Command jsonschema-gen reads a JSON Schema document and writes the Go
+// struct declarations jsonschema.GenerateGo derives from it, as a starting
+// point for evolving them with this module's tag-driven workflow.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mjarkk/jsonschema"
+)
+
+func main() {
+	pkg := flag.String("pkg", "main", "package name for the generated file")
+	in := flag.String("in", "-", "schema file to read, or - for stdin")
+	out := flag.String("out", "-", "file to write the generated Go source to, or - for stdout")
+	flag.Parse()
+
+	if err := run(*pkg, *in, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "jsonschema-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pkg, in, out string) error {
+	input := os.Stdin
+	if in != "-" {
+		f, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	schema, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	output := os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		output = f
+	}
+
+	return jsonschema.GenerateGo(schema, pkg, output)
+}
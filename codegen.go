@@ -0,0 +1,358 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// rawSchema is a loose JSON Schema decoding used only by GenerateGo. Unlike
+// Property, `additionalProperties` may be a bool or a nested schema, so it
+// is kept as json.RawMessage and inspected lazily.
+type rawSchema struct {
+	Ref                  string               `json:"$ref,omitempty"`
+	Type                 json.RawMessage      `json:"type,omitempty"`
+	Title                string               `json:"title,omitempty"`
+	Properties           map[string]rawSchema `json:"properties,omitempty"`
+	Required             []string             `json:"required,omitempty"`
+	Items                *rawSchema           `json:"items,omitempty"`
+	Enum                 []json.RawMessage    `json:"enum,omitempty"`
+	Format               string               `json:"format,omitempty"`
+	AdditionalProperties json.RawMessage      `json:"additionalProperties,omitempty"`
+	OneOf                []rawSchema          `json:"oneOf,omitempty"`
+	AnyOf                []rawSchema          `json:"anyOf,omitempty"`
+}
+
+type rawDocument struct {
+	rawSchema
+	Defs map[string]rawSchema `json:"$defs,omitempty"`
+}
+
+func (s rawSchema) typeName() string {
+	var name string
+	if err := json.Unmarshal(s.Type, &name); err == nil {
+		return name
+	}
+	var names []string
+	if err := json.Unmarshal(s.Type, &names); err == nil {
+		for _, n := range names {
+			if n != "null" {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
+// GenerateGo reads a JSON Schema document (draft-07/2020-12) and writes Go
+// struct declarations for it to w: one type per `$defs` entry, with
+// `json`/`jsonSchema` tags such that feeding the result back through From
+// reproduces the property names, types, required set and enum values.
+// $ref/$defs each become a named type, enum becomes a named type plus typed
+// constants, oneOf/anyOf become an interface plus one implementer per
+// branch, and additionalProperties with a schema becomes map[string]T.
+// Those last two do not round-trip: From rejects interface types, and
+// describes a map as a plain object without its additionalProperties.
+func GenerateGo(schema []byte, pkg string, w io.Writer) error {
+	var doc rawDocument
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return fmt.Errorf("jsonschema: parse schema: %w", err)
+	}
+
+	g := &goGenerator{defs: doc.Defs, named: map[string]bool{}, imports: map[string]bool{}}
+
+	for _, key := range sortedKeys(doc.Defs) {
+		if _, err := g.namedType(goIdent(key), doc.Defs[key]); err != nil {
+			return fmt.Errorf("jsonschema: $defs[%q]: %w", key, err)
+		}
+	}
+
+	rootName := "Root"
+	if doc.Title != "" {
+		rootName = goIdent(doc.Title)
+	}
+	if _, err := g.namedType(rootName, doc.rawSchema); err != nil {
+		return fmt.Errorf("jsonschema: root schema: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, path := range sortedSet(g.imports) {
+			fmt.Fprintf(&buf, "\t%q\n", path)
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, decl := range g.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n\n")
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		// Emitting the unformatted source is more useful than nothing to a
+		// caller debugging a generator bug.
+		_, _ = w.Write(buf.Bytes())
+		return fmt.Errorf("jsonschema: formatting generated source: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+type goGenerator struct {
+	defs    map[string]rawSchema
+	named   map[string]bool
+	decls   []string
+	imports map[string]bool
+}
+
+// namedType emits (if not already emitted) a top-level declaration called
+// name for s, and returns the Go type expression referring to it.
+func (g *goGenerator) namedType(name string, s rawSchema) (string, error) {
+	if g.named[name] {
+		return name, nil
+	}
+	g.named[name] = true
+
+	switch {
+	case len(s.Enum) > 0:
+		return name, g.emitEnum(name, s)
+	case len(s.OneOf) > 0:
+		return name, g.emitUnion(name, s.OneOf)
+	case len(s.AnyOf) > 0:
+		return name, g.emitUnion(name, s.AnyOf)
+	case s.typeName() == "object" && len(s.Properties) == 0:
+		if additional, ok := g.additionalPropertiesType(name, s); ok {
+			g.decls = append(g.decls, fmt.Sprintf("type %s map[string]%s", name, additional))
+			return name, nil
+		}
+		return name, g.emitStruct(name, s)
+	case s.typeName() == "object" || len(s.Properties) > 0:
+		return name, g.emitStruct(name, s)
+	default:
+		// A named scalar/array alias, e.g. `type Tags []string`.
+		underlying, err := g.typeExpr(name, s)
+		if err != nil {
+			return "", err
+		}
+		g.decls = append(g.decls, fmt.Sprintf("type %s %s", name, underlying))
+		return name, nil
+	}
+}
+
+func (g *goGenerator) emitEnum(name string, s rawSchema) error {
+	base := "string"
+	if s.typeName() == "integer" || s.typeName() == "number" {
+		base = "int64"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s %s\n\nconst (\n", name, base)
+	constNames := make([]string, len(s.Enum))
+	for i, raw := range s.Enum {
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("enum value %s: %w", raw, err)
+		}
+		constName := fmt.Sprintf("%s%s", name, goIdent(fmt.Sprint(value)))
+		if constName == name {
+			constName = fmt.Sprintf("%s%d", name, i)
+		}
+		constNames[i] = constName
+		fmt.Fprintf(&b, "\t%s %s = %#v\n", constName, name, value)
+	}
+	b.WriteString(")")
+	g.decls = append(g.decls, b.String())
+
+	// Wire the generated type back into From's enum support, so feeding it
+	// back through From reproduces the original `enum` keyword.
+	g.decls = append(g.decls, fmt.Sprintf(
+		"func (%s) JSONSchemaEnum() []any {\n\treturn []any{%s}\n}",
+		name, strings.Join(constNames, ", "),
+	))
+	return nil
+}
+
+func (g *goGenerator) emitUnion(name string, branches []rawSchema) error {
+	marker := "is" + name
+	g.decls = append(g.decls, fmt.Sprintf("type %s interface {\n\t%s()\n}", name, marker))
+
+	for i, branch := range branches {
+		implName := goIdent(branch.Title)
+		if implName == "" {
+			implName = fmt.Sprintf("%s%d", name, i+1)
+		}
+		if _, err := g.namedType(implName, branch); err != nil {
+			return err
+		}
+		g.decls = append(g.decls, fmt.Sprintf("func (%s) %s() {}", implName, marker))
+	}
+	return nil
+}
+
+func (g *goGenerator) emitStruct(name string, s rawSchema) error {
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, fieldName := range sortedKeys(s.Properties) {
+		fieldSchema := s.Properties[fieldName]
+		goName := goIdent(fieldName)
+
+		fieldType, err := g.typeExpr(name+goName, fieldSchema)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", fieldName, err)
+		}
+
+		isRequired := required[fieldName]
+		isCollection := strings.HasPrefix(fieldType, "[]") || strings.HasPrefix(fieldType, "map[")
+		tag := fmt.Sprintf("json:%q", fieldName)
+		switch {
+		case isRequired && isCollection:
+			tag += ` jsonSchema:"required"`
+		case !isRequired && !isCollection:
+			fieldType = "*" + fieldType
+		}
+
+		fmt.Fprintf(&b, "\t%s %s `%s`\n", goName, fieldType, tag)
+	}
+	b.WriteString("}")
+	g.decls = append(g.decls, b.String())
+
+	if additional, ok := g.additionalPropertiesType(name, s); ok {
+		g.decls = append(g.decls, fmt.Sprintf("// The schema for %s also allows additional properties typed %s; %s has no field holding them.", name, additional, name))
+	}
+	return nil
+}
+
+func (g *goGenerator) additionalPropertiesType(parentName string, s rawSchema) (string, bool) {
+	if len(s.AdditionalProperties) == 0 {
+		return "", false
+	}
+	var asBool bool
+	if err := json.Unmarshal(s.AdditionalProperties, &asBool); err == nil {
+		return "", false
+	}
+	var nested rawSchema
+	if err := json.Unmarshal(s.AdditionalProperties, &nested); err != nil {
+		return "", false
+	}
+	valueType, err := g.typeExpr(parentName+"Value", nested)
+	if err != nil {
+		return "", false
+	}
+	return valueType, true
+}
+
+// typeExpr returns the Go type expression for s, emitting a new named
+// declaration (using hintName) when s describes an object, enum, or union
+// that isn't already a $ref to one. An object with no properties and a
+// schema-valued additionalProperties becomes an inline map[string]T instead.
+func (g *goGenerator) typeExpr(hintName string, s rawSchema) (string, error) {
+	if s.Ref != "" {
+		key := s.Ref
+		if idx := strings.LastIndex(key, "/"); idx >= 0 {
+			key = key[idx+1:]
+		}
+		def, ok := g.defs[key]
+		if !ok {
+			return "", fmt.Errorf("unresolved $ref %q", s.Ref)
+		}
+		return g.namedType(goIdent(key), def)
+	}
+
+	if formatType, ok := goTypeForFormat[s.Format]; ok {
+		if formatType.importPath != "" {
+			g.imports[formatType.importPath] = true
+		}
+		return formatType.goType, nil
+	}
+
+	switch {
+	case len(s.Enum) > 0, len(s.OneOf) > 0, len(s.AnyOf) > 0:
+		return g.namedType(hintName, s)
+	case s.typeName() == "object" || len(s.Properties) > 0:
+		if additional, ok := g.additionalPropertiesType(hintName, s); ok && len(s.Properties) == 0 {
+			return "map[string]" + additional, nil
+		}
+		return g.namedType(hintName, s)
+	case s.typeName() == "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		elem, err := g.typeExpr(hintName+"Item", *s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case s.typeName() == "string":
+		return "string", nil
+	case s.typeName() == "integer":
+		return "int64", nil
+	case s.typeName() == "number":
+		return "float64", nil
+	case s.typeName() == "boolean":
+		return "bool", nil
+	default:
+		return "any", nil
+	}
+}
+
+type formatType struct {
+	goType     string
+	importPath string
+}
+
+// goTypeForFormat maps a string `format` to the Go type From auto-detects
+// it from. Note "duration" isn't here: it's a string format, but From never
+// auto-detects it for the stdlib time.Duration (which marshals as a plain
+// JSON number) - see schema.go's durationType case.
+var goTypeForFormat = map[string]formatType{
+	"date-time": {"time.Time", "time"},
+	"uuid":      {"uuid.UUID", "github.com/google/uuid"},
+	"objectid":  {"primitive.ObjectID", "go.mongodb.org/mongo-driver/bson/primitive"},
+}
+
+func goIdent(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if out == "" {
+		return "Value"
+	}
+	if unicode.IsDigit(rune(out[0])) {
+		out = "V" + out
+	}
+	return strings.ToUpper(out[:1]) + out[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedSet(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
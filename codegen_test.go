@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGo(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"title": "Order",
+		"properties": {
+			"id": {"type": "string"},
+			"status": {"type": "string", "enum": ["pending", "shipped"]},
+			"total": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"customer": {"$ref": "#/$defs/Customer"}
+		},
+		"required": ["id", "status", "customer"],
+		"$defs": {
+			"Customer": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}},
+				"required": ["name"]
+			}
+		}
+	}`)
+
+	var buf bytes.Buffer
+	NoError(t, GenerateGo(schema, "example", &buf))
+
+	src := buf.String()
+	Contains(t, src, "package example")
+	Contains(t, src, "type Customer struct")
+	Contains(t, src, "Name string `json:\"name\"`")
+	Contains(t, src, "type OrderStatus string")
+	Contains(t, src, `OrderStatusPending OrderStatus = "pending"`)
+	Contains(t, src, "type Order struct")
+	Contains(t, src, "Customer Customer")
+	Contains(t, src, "*float64")
+	Contains(t, src, "[]string")
+}
+
+func TestGenerateGoEmitsJSONSchemaEnumMethod(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"title": "Order",
+		"properties": {
+			"status": {"type": "string", "enum": ["pending", "shipped"]}
+		},
+		"required": ["status"]
+	}`)
+
+	var buf bytes.Buffer
+	NoError(t, GenerateGo(schema, "example", &buf))
+
+	src := buf.String()
+	Contains(t, src, "func (OrderStatus) JSONSchemaEnum() []any {")
+	Contains(t, src, "return []any{OrderStatusPending, OrderStatusShipped}")
+}
+
+func TestGenerateGoEmitsMapForRootAdditionalProperties(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"title": "Bag",
+		"properties": {},
+		"additionalProperties": {"type": "string"}
+	}`)
+
+	var buf bytes.Buffer
+	NoError(t, GenerateGo(schema, "example", &buf))
+
+	src := buf.String()
+	Contains(t, src, "type Bag map[string]string")
+	NotContains(t, src, "type Bag struct")
+}
+
+func TestGenerateGoEmitsMapForDefAdditionalProperties(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"title": "Root",
+		"properties": {
+			"bag": {"$ref": "#/$defs/Bag"}
+		},
+		"required": ["bag"],
+		"$defs": {
+			"Bag": {
+				"type": "object",
+				"properties": {},
+				"additionalProperties": {"type": "integer"}
+			}
+		}
+	}`)
+
+	var buf bytes.Buffer
+	NoError(t, GenerateGo(schema, "example", &buf))
+
+	src := buf.String()
+	Contains(t, src, "type Bag map[string]int64")
+	NotContains(t, src, "type Bag struct")
+}
+
+func TestGenerateGoRoundTripsThroughFrom(t *testing.T) {
+	type Customer struct {
+		Name string
+	}
+	type Order struct {
+		ID       string
+		Customer Customer
+		Total    *float64
+	}
+
+	schema, err := Generate(Order{})
+	NoError(t, err)
+	schemaJSON, err := schema.MarshalJSON()
+	NoError(t, err)
+
+	var buf bytes.Buffer
+	NoError(t, GenerateGo(schemaJSON, "example", &buf))
+	Contains(t, buf.String(), "type Root struct")
+	Contains(t, buf.String(), "*float64")
+}
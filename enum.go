@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// JSONSchemaEnum lets a named type (typically a string or integer alias
+// with a handful of const values) expose its allowed values without
+// implementing the heavier JSONSchemaDescribe.
+type JSONSchemaEnum interface {
+	JSONSchemaEnum() []any
+}
+
+var enumInterface = reflect.TypeOf((*JSONSchemaEnum)(nil)).Elem()
+
+// enumRegistry holds enum values for types that don't implement
+// JSONSchemaEnum themselves, registered via RegisterEnum.
+var enumRegistry = map[reflect.Type][]any{}
+
+// RegisterEnum declares the allowed values for t, for types you don't own
+// and so can't implement JSONSchemaEnum on directly. From consults this
+// registry for every field whose type doesn't implement JSONSchemaEnum.
+//
+// Go has no reflection access to a type's declared const block, so there's
+// no way to discover values automatically; pass them explicitly.
+func RegisterEnum(t reflect.Type, values ...any) {
+	enumRegistry[t] = values
+}
+
+// attachEnum fills in property.Enum from ft's JSONSchemaEnum implementation
+// or its RegisterEnum entry, if either exists, validating that every value
+// marshals to JSON matching property.Type.
+func attachEnum(ft reflect.Type, property *Property) error {
+	values := enumValues(ft)
+	if values == nil {
+		return nil
+	}
+
+	enum := make([]json.RawMessage, len(values))
+	for i, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("enum value %v: %w", value, err)
+		}
+		if err := checkEnumValueType(property.Type, raw); err != nil {
+			return fmt.Errorf("enum value %v: %w", value, err)
+		}
+		enum[i] = raw
+	}
+	property.Enum = enum
+	return nil
+}
+
+func enumValues(ft reflect.Type) []any {
+	if reflect.PointerTo(ft).Implements(enumInterface) || ft.Implements(enumInterface) {
+		if enum, ok := reflect.New(ft).Interface().(JSONSchemaEnum); ok {
+			return enum.JSONSchemaEnum()
+		}
+	}
+	return enumRegistry[ft]
+}
+
+func checkEnumValueType(propertyType PropertyType, raw json.RawMessage) error {
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return err
+	}
+
+	switch propertyType {
+	case PropertyTypeString:
+		if _, ok := decoded.(string); !ok {
+			return fmt.Errorf("does not match declared type %q", propertyType)
+		}
+	case PropertyTypeInteger:
+		if n, ok := decoded.(float64); !ok || n != math.Trunc(n) {
+			return fmt.Errorf("does not match declared type %q", propertyType)
+		}
+	case PropertyTypeNumber:
+		if _, ok := decoded.(float64); !ok {
+			return fmt.Errorf("does not match declared type %q", propertyType)
+		}
+	case PropertyTypeBoolean:
+		if _, ok := decoded.(bool); !ok {
+			return fmt.Errorf("does not match declared type %q", propertyType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+type Color string
+
+func (Color) JSONSchemaEnum() []any {
+	return []any{Color("red"), Color("green"), Color("blue")}
+}
+
+type Priority int
+
+func TestFromWithJSONSchemaEnum(t *testing.T) {
+	property, err := From(
+		struct{ C Color }{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+	)
+	NoError(t, err)
+	c := property.Properties["C"]
+	Equal(t, PropertyTypeString, c.Type)
+	Equal(t, []byte(`"red"`), []byte(c.Enum[0]))
+	Equal(t, []byte(`"green"`), []byte(c.Enum[1]))
+	Equal(t, []byte(`"blue"`), []byte(c.Enum[2]))
+}
+
+func TestFromWithRegisterEnum(t *testing.T) {
+	RegisterEnum(reflect.TypeOf(Priority(0)), Priority(1), Priority(2), Priority(3))
+	defer delete(enumRegistry, reflect.TypeOf(Priority(0)))
+
+	property, err := From(
+		struct{ P Priority }{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+	)
+	NoError(t, err)
+	p := property.Properties["P"]
+	Equal(t, PropertyTypeInteger, p.Type)
+	Len(t, p.Enum, 3)
+}
+
+func TestFromWithMismatchedEnumType(t *testing.T) {
+	type BadColor string
+	RegisterEnum(reflect.TypeOf(BadColor("")), 1, 2, 3)
+	defer delete(enumRegistry, reflect.TypeOf(BadColor("")))
+
+	_, err := From(
+		struct{ C BadColor }{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+	)
+	Error(t, err)
+}
@@ -0,0 +1,80 @@
+package jsonschema
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// formatCheckers holds validators for the `format` keyword, keyed by format
+// name. It is populated by RegisterFormat; Validate consults it for every
+// string property that declares a Format.
+var formatCheckers = map[string]func(value any) bool{}
+
+// RegisterFormat adds or overrides the checker used for the `format`
+// keyword named name. check receives the decoded JSON value (a string,
+// for every format Validate currently ships) and reports whether it
+// satisfies the format.
+func RegisterFormat(name string, check func(value any) bool) {
+	formatCheckers[name] = check
+}
+
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+func init() {
+	RegisterFormat("date-time", stringFormat(func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	}))
+	RegisterFormat("date", stringFormat(func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	}))
+	RegisterFormat("time", stringFormat(func(s string) bool {
+		_, err := time.Parse("15:04:05", s)
+		return err == nil
+	}))
+	RegisterFormat("email", stringFormat(emailPattern.MatchString))
+	RegisterFormat("uri", stringFormat(func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	}))
+	RegisterFormat("uuid", stringFormat(func(s string) bool {
+		_, err := uuid.Parse(s)
+		return err == nil
+	}))
+	RegisterFormat("ipv4", stringFormat(func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil
+	}))
+	RegisterFormat("ipv6", stringFormat(func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() == nil
+	}))
+	// Not auto-detected for the stdlib time.Duration, which marshals as a
+	// plain JSON number rather than a string (see schema.go's durationType
+	// case); useful for a wrapper type that implements JSONSchemaDescribe
+	// to actually marshal to a duration string.
+	RegisterFormat("duration", stringFormat(func(s string) bool {
+		_, err := time.ParseDuration(s)
+		return err == nil
+	}))
+	RegisterFormat("objectid", stringFormat(func(s string) bool {
+		_, err := primitive.ObjectIDFromHex(s)
+		return err == nil
+	}))
+}
+
+func stringFormat(check func(string) bool) func(any) bool {
+	return func(value any) bool {
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return check(s)
+	}
+}
@@ -0,0 +1,72 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFromDetectsWellKnownFormats(t *testing.T) {
+	property, err := From(
+		struct {
+			CreatedAt time.Time
+			ID        uuid.UUID
+			Addr      net.IP
+			TTL       time.Duration
+			DocID     primitive.ObjectID
+		}{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+	)
+	NoError(t, err)
+	Equal(t, PropertyTypeString, property.Properties["CreatedAt"].Type)
+	Equal(t, "date-time", property.Properties["CreatedAt"].Format)
+	Equal(t, "uuid", property.Properties["ID"].Format)
+	Equal(t, "ipv4", property.Properties["Addr"].Format)
+	Equal(t, "objectid", property.Properties["DocID"].Format)
+
+	// A bare time.Duration marshals as a JSON number (nanoseconds), not a
+	// string, so it's described as the integer it actually is rather than
+	// claiming a string format its own real payloads would fail.
+	Equal(t, PropertyTypeInteger, property.Properties["TTL"].Type)
+	Empty(t, property.Properties["TTL"].Format)
+}
+
+func TestTimeDurationSchemaAcceptsItsOwnMarshaledJSON(t *testing.T) {
+	type WithTTL struct {
+		TTL time.Duration
+	}
+	value := WithTTL{TTL: 5 * time.Second}
+
+	property, err := From(
+		value,
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+	)
+	NoError(t, err)
+
+	data, err := json.Marshal(value)
+	NoError(t, err)
+	Empty(t, Validate(property, data))
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("even-length", func(value any) bool {
+		s, ok := value.(string)
+		return ok && len(s)%2 == 0
+	})
+	defer delete(formatCheckers, "even-length")
+
+	schema := Property{Type: PropertyTypeString, Format: "even-length"}
+	Empty(t, Validate(schema, []byte(`"abcd"`)))
+	NotEmpty(t, Validate(schema, []byte(`"abc"`)))
+}
@@ -0,0 +1,103 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// DraftVersion is the JSON Schema dialect Generate advertises via `$schema`.
+const DraftVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a complete, self-contained JSON Schema document: a root
+// Property plus the `$defs` map referenced by any `$ref` inside it.
+type Schema struct {
+	Schema string              `json:"$schema,omitempty"`
+	Defs   map[string]Property `json:"$defs,omitempty"`
+	Property
+}
+
+// Generate builds a single self-contained Schema for v. Unlike From, named
+// nested structs are, by default, pulled out into `$defs` and referenced
+// via `$ref` rather than requiring the caller to assemble them from
+// callbacks. Pass WithInline to get the old inlined-everywhere behavior.
+func Generate(v any, opts ...Option) (*Schema, error) {
+	options := &genOptions{refMode: refModeDefs}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	registry := newDefRegistry()
+	defs := map[string]Property{}
+	onNewProperty := func(key string, property Property) {
+		defs[key] = property
+	}
+	filter := func(string) bool { return options.refMode == refModeDefs }
+
+	root, err := from(v, "#/$defs/", onNewProperty, filter, nil, options, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{Schema: DraftVersion, Property: root}
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	return schema, nil
+}
+
+// MarshalJSON is defined explicitly because Property already implements
+// json.Marshaler; without this, that method would be promoted onto Schema
+// and the $schema/$defs fields would never be marshaled.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	propertyJSON, err := json.Marshal(s.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(propertyJSON, &fields); err != nil {
+		return nil, err
+	}
+	if s.Schema != "" {
+		b, err := json.Marshal(s.Schema)
+		if err != nil {
+			return nil, err
+		}
+		fields["$schema"] = b
+	}
+	if len(s.Defs) > 0 {
+		b, err := json.Marshal(s.Defs)
+		if err != nil {
+			return nil, err
+		}
+		fields["$defs"] = b
+	}
+	return json.Marshal(fields)
+}
+
+// defsKey is the $defs key Generate uses for a named nested struct: its
+// package-qualified name (e.g. "jsonschema.Inner"), matching the standard
+// draft-07/2020-12 layout. From's own ref format (refKey, in schema.go) is
+// unaffected by this; it's kept as-is for From's existing callback API.
+func defsKey(t reflect.Type) string {
+	return t.String()
+}
+
+// anonDefsKey names a $defs entry for an anonymous (nameless) struct type,
+// which has no declared name for defsKey to key off of. Entries are named
+// after a prefix of their content hash instead, so two unrelated anonymous
+// shapes never collide on the empty string and identical ones share a name.
+func anonDefsKey(hash string) string {
+	return "Anon" + hash[:12]
+}
+
+func contentHash(p Property) string {
+	// Properties are built from reflect.Type, so json.Marshal here never
+	// fails; a hash collision only drops a legitimately duplicate $defs
+	// entry, never a distinct one.
+	b, _ := json.Marshal(p)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDefaultsToRefs(t *testing.T) {
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		A Inner
+	}
+
+	schema, err := Generate(Outer{})
+	NoError(t, err)
+	Equal(t, DraftVersion, schema.Schema)
+
+	ref := schema.Property.Properties["A"].Ref
+	NotEmpty(t, ref)
+	_, ok := schema.Defs[ref[len("#/$defs/"):]]
+	True(t, ok)
+}
+
+func TestGenerateWithInline(t *testing.T) {
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		A Inner
+	}
+
+	schema, err := Generate(Outer{}, WithInline())
+	NoError(t, err)
+	Empty(t, schema.Defs)
+	Equal(t, PropertyTypeObject, schema.Property.Properties["A"].Type)
+}
+
+func TestGenerateUsesPkgQualifiedDefsKeys(t *testing.T) {
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		A Inner
+	}
+
+	schema, err := Generate(Outer{})
+	NoError(t, err)
+
+	ref := schema.Property.Properties["A"].Ref
+	Equal(t, "#/$defs/jsonschema.Inner", ref)
+	_, ok := schema.Defs["jsonschema.Inner"]
+	True(t, ok)
+}
+
+func TestGenerateDedupsIdenticalAnonymousStructs(t *testing.T) {
+	type Outer struct {
+		A struct{ Name string }
+		B struct{ Name string }
+	}
+
+	schema, err := Generate(Outer{})
+	NoError(t, err)
+	Len(t, schema.Defs, 1)
+	Equal(t, schema.Property.Properties["A"].Ref, schema.Property.Properties["B"].Ref)
+
+	ref := schema.Property.Properties["A"].Ref
+	NotEmpty(t, ref)
+	NotEqual(t, "#/$defs/", ref)
+}
+
+func TestGenerateDoesNotDedupeUnrelatedNamedStructsWithTheSameShape(t *testing.T) {
+	type User struct {
+		Name string
+	}
+	type Product struct {
+		Name string
+	}
+	type Outer struct {
+		A User
+		B Product
+	}
+
+	schema, err := Generate(Outer{})
+	NoError(t, err)
+	Len(t, schema.Defs, 2)
+	NotEqual(t, schema.Property.Properties["A"].Ref, schema.Property.Properties["B"].Ref)
+	Equal(t, "#/$defs/jsonschema.User", schema.Property.Properties["A"].Ref)
+	Equal(t, "#/$defs/jsonschema.Product", schema.Property.Properties["B"].Ref)
+}
+
+func TestGenerateHandlesSelfReferentialStructs(t *testing.T) {
+	type RecNode struct {
+		Name     string
+		Children []RecNode
+	}
+
+	schema, err := Generate(RecNode{})
+	NoError(t, err)
+
+	children := schema.Property.Properties["Children"]
+	Equal(t, PropertyTypeArray, children.Type)
+
+	ref := children.Items.Ref
+	NotEmpty(t, ref)
+	def, ok := schema.Defs[ref[len("#/$defs/"):]]
+	True(t, ok)
+	Equal(t, ref, def.Properties["Children"].Items.Ref)
+}
+
+func TestGenerateWithInlineRejectsSelfReferentialStructs(t *testing.T) {
+	type RecNode struct {
+		Name     string
+		Children []RecNode
+	}
+
+	_, err := Generate(RecNode{}, WithInline())
+	Error(t, err)
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	schema := &Schema{
+		Schema: DraftVersion,
+		Defs: map[string]Property{
+			"Inner": {Type: PropertyTypeObject},
+		},
+		Property: Property{Type: PropertyTypeObject},
+	}
+
+	b, err := json.Marshal(schema)
+	NoError(t, err)
+	JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {"Inner": {"type": "object"}},
+		"type": "object"
+	}`, string(b))
+}
@@ -0,0 +1,52 @@
+package jsonschema
+
+// Option configures From and Generate.
+type Option func(*genOptions)
+
+type refMode int
+
+const (
+	// refModeInline expands nested named structs in place.
+	refModeInline refMode = iota
+	// refModeDefs splits nested named structs out behind a `$ref` into a
+	// shared `$defs` map.
+	refModeDefs
+)
+
+type genOptions struct {
+	refMode refMode
+	strict  bool
+}
+
+func newOptions(opts []Option) *genOptions {
+	o := &genOptions{refMode: refModeInline}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithRefs makes Generate emit named nested structs as `$ref`s into
+// `$defs` instead of inlining them. This is Generate's default.
+func WithRefs() Option {
+	return func(o *genOptions) { o.refMode = refModeDefs }
+}
+
+// WithInline makes Generate inline every nested struct in place instead of
+// splitting it out into `$defs`.
+func WithInline() Option {
+	return func(o *genOptions) { o.refMode = refModeInline }
+}
+
+// defRegistry de-duplicates identical anonymous (nameless) struct shapes
+// discovered while walking a value, so two anonymous struct fields with the
+// same fields share a single $defs entry. Named struct types are always
+// keyed by their own pkg.TypeName and never deduped this way, even when two
+// unrelated ones happen to share a shape.
+type defRegistry struct {
+	byHash map[string]string // content hash -> ref key
+}
+
+func newDefRegistry() *defRegistry {
+	return &defRegistry{byHash: map[string]string{}}
+}
@@ -0,0 +1,450 @@
+// Package jsonschema generates JSON Schema documents from Go struct
+// declarations using reflection and struct tags.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PropertyType is one of the JSON Schema primitive types.
+type PropertyType string
+
+const (
+	PropertyTypeString  PropertyType = "string"
+	PropertyTypeNumber  PropertyType = "number"
+	PropertyTypeInteger PropertyType = "integer"
+	PropertyTypeBoolean PropertyType = "boolean"
+	PropertyTypeArray   PropertyType = "array"
+	PropertyTypeObject  PropertyType = "object"
+	PropertyTypeNull    PropertyType = "null"
+)
+
+// Property is a single JSON Schema node. From builds up a tree of these to
+// describe a Go value.
+type Property struct {
+	Type                 PropertyType        `json:"type,omitempty"`
+	Title                string              `json:"title,omitempty"`
+	Description          string              `json:"description,omitempty"`
+	Ref                  string              `json:"$ref,omitempty"`
+	Properties           map[string]Property `json:"properties,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	AdditionalProperties *bool               `json:"additionalProperties,omitempty"`
+	Items                *Property           `json:"items,omitempty"`
+	MinItems             *uint               `json:"minItems,omitempty"`
+	MaxItems             *uint               `json:"maxItems,omitempty"`
+	UniqueItems          bool                `json:"uniqueItems,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	Format               string              `json:"format,omitempty"`
+	Enum                 []json.RawMessage   `json:"enum,omitempty"`
+	Examples             []json.RawMessage   `json:"examples,omitempty"`
+	Deprecated           bool                `json:"deprecated,omitempty"`
+
+	// Nullable marks the property as also accepting null, per WithStrict's
+	// `type: ["...", "null"]` convention. It is not a JSON Schema keyword
+	// itself, so MarshalJSON folds it into Type (or, for a `$ref`, into an
+	// anyOf) instead of emitting it as its own field.
+	Nullable bool `json:"-"`
+}
+
+// MarshalJSON folds Nullable into Type as a two-element union
+// (`["string","null"]`) instead of emitting it as its own keyword. A
+// nullable `$ref` property has no `type` to union, so it's instead
+// rewritten into `anyOf: [{$ref}, {type: "null"}]`.
+func (p Property) MarshalJSON() ([]byte, error) {
+	type alias Property
+	raw, err := json.Marshal(alias(p))
+	if err != nil {
+		return nil, err
+	}
+	if !p.Nullable {
+		return raw, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.Type != "":
+		typeUnion, err := json.Marshal([2]PropertyType{p.Type, PropertyTypeNull})
+		if err != nil {
+			return nil, err
+		}
+		fields["type"] = typeUnion
+	case p.Ref != "":
+		delete(fields, "$ref")
+		anyOf, err := json.Marshal([2]Property{{Ref: p.Ref}, {Type: PropertyTypeNull}})
+		if err != nil {
+			return nil, err
+		}
+		fields["anyOf"] = anyOf
+	default:
+		return raw, nil
+	}
+	return json.Marshal(fields)
+}
+
+// JSONSchemaDescribe lets a type fully own its schema representation instead
+// of having From derive one from its fields.
+type JSONSchemaDescribe interface {
+	JSONSchemaDescribe() Property
+}
+
+// WithMeta carries root-level metadata that From stamps onto the schema it
+// returns.
+type WithMeta struct {
+	Title       string
+	Description string
+}
+
+var (
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	objectIDType      = reflect.TypeOf(primitive.ObjectID{})
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	uuidType          = reflect.TypeOf(uuid.UUID{})
+	ipType            = reflect.TypeOf(net.IP{})
+	describeInterface = reflect.TypeOf((*JSONSchemaDescribe)(nil)).Elem()
+)
+
+// From builds a Property describing v, which must be a struct or a pointer
+// to one.
+//
+// refPrefix is prepended to every `$ref` generated for named nested structs.
+// onNewProperty is called once for every such nested struct with its ref key
+// and schema. filter is consulted with the full ref (refPrefix+key) and
+// decides whether the nested struct is split out behind a `$ref` (true) or
+// inlined in place (false). meta, if non-nil, is copied onto the root
+// property.
+func From(
+	v any,
+	refPrefix string,
+	onNewProperty func(key string, property Property),
+	filter func(key string) bool,
+	meta *WithMeta,
+	opts ...Option,
+) (Property, error) {
+	return from(v, refPrefix, onNewProperty, filter, meta, newOptions(opts), nil)
+}
+
+func from(
+	v any,
+	refPrefix string,
+	onNewProperty func(key string, property Property),
+	filter func(key string) bool,
+	meta *WithMeta,
+	options *genOptions,
+	registry *defRegistry,
+) (Property, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return Property{}, fmt.Errorf("jsonschema: value must be a struct, got nil")
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return Property{}, fmt.Errorf("jsonschema: value must be a struct, got %s", t.Kind())
+	}
+
+	g := &generator{
+		refPrefix:     refPrefix,
+		onNewProperty: onNewProperty,
+		filter:        filter,
+		options:       options,
+		registry:      registry,
+		inFlight:      map[string]bool{},
+	}
+	property, err := g.fromStruct(t)
+	if err != nil {
+		return Property{}, err
+	}
+	if meta != nil {
+		if meta.Title != "" {
+			property.Title = meta.Title
+		}
+		if meta.Description != "" {
+			property.Description = meta.Description
+		}
+	}
+	return property, nil
+}
+
+type generator struct {
+	refPrefix     string
+	onNewProperty func(key string, property Property)
+	filter        func(key string) bool
+	options       *genOptions
+	registry      *defRegistry
+
+	// inFlight holds the refKey of every nested struct currently being
+	// walked, so a cycle (e.g. a tree or linked-list type) is caught by
+	// reentry instead of recursing until the stack overflows.
+	inFlight map[string]bool
+}
+
+func (g *generator) fromStruct(t reflect.Type) (Property, error) {
+	property := Property{
+		Type:       PropertyTypeObject,
+		Properties: map[string]Property{},
+		Required:   []string{},
+	}
+	var names []string
+
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			// Its fields are promoted and already walked individually below.
+			continue
+		}
+
+		jsonTag := field.Tag.Get("json")
+		jsonParts := strings.Split(jsonTag, ",")
+		name := field.Name
+		if jsonParts[0] == "-" {
+			continue
+		}
+		if jsonParts[0] != "" {
+			name = jsonParts[0]
+		}
+
+		fieldProperty, required, err := g.fromField(field)
+		if err != nil {
+			return Property{}, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if g.options.strict {
+			if err := checkStrictSupported(fieldProperty); err != nil {
+				return Property{}, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		property.Properties[name] = fieldProperty
+		names = append(names, name)
+		if required {
+			property.Required = append(property.Required, name)
+		}
+	}
+
+	if g.options.strict {
+		makeStrict(&property, names)
+	}
+
+	return property, nil
+}
+
+func (g *generator) fromField(field reflect.StructField) (Property, bool, error) {
+	ft := field.Type
+	isPtr := ft.Kind() == reflect.Ptr
+	if isPtr {
+		ft = ft.Elem()
+	}
+	isSliceOrArray := (ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array) && ft != objectIDType
+	required := !isPtr && !isSliceOrArray
+
+	schemaTag := field.Tag.Get("jsonSchema")
+	schemaParts := map[string]bool{}
+	for _, part := range strings.Split(schemaTag, ",") {
+		if part != "" {
+			schemaParts[part] = true
+		}
+	}
+	if schemaParts["required"] {
+		required = true
+	}
+	if schemaParts["notRequired"] {
+		required = false
+	}
+
+	property, err := g.fromType(ft)
+	if err != nil {
+		return Property{}, false, err
+	}
+
+	if schemaParts["deprecated"] {
+		property.Deprecated = true
+	}
+	if schemaParts["uniqueItems"] {
+		property.UniqueItems = true
+	}
+
+	return property, required, nil
+}
+
+func (g *generator) fromType(ft reflect.Type) (Property, error) {
+	if reflect.PointerTo(ft).Implements(describeInterface) || ft.Implements(describeInterface) {
+		described, ok := reflect.New(ft).Interface().(JSONSchemaDescribe)
+		if ok {
+			property := described.JSONSchemaDescribe()
+			validateRawJSON("enum", property.Enum)
+			validateRawJSON("example", property.Examples)
+			return property, nil
+		}
+	}
+
+	property, err := g.baseProperty(ft)
+	if err != nil {
+		return Property{}, err
+	}
+	if err := attachEnum(ft, &property); err != nil {
+		return Property{}, fmt.Errorf("type %s: %w", ft, err)
+	}
+	return property, nil
+}
+
+func (g *generator) baseProperty(ft reflect.Type) (Property, error) {
+	switch ft {
+	case rawMessageType:
+		return Property{}, nil
+	case objectIDType:
+		return Property{Type: PropertyTypeString, Format: "objectid"}, nil
+	case timeType:
+		return Property{Type: PropertyTypeString, Format: "date-time"}, nil
+	case uuidType:
+		return Property{Type: PropertyTypeString, Format: "uuid"}, nil
+	case durationType:
+		// encoding/json marshals a bare time.Duration as its number of
+		// nanoseconds (an int64), not a string, so claiming the string
+		// format "duration" here would make a generated schema reject its
+		// own real payloads. Describe it as the integer it actually
+		// (de)serializes to; wrap it in a type with custom JSON marshaling
+		// if you want an ISO-8601 duration string instead.
+		return Property{Type: PropertyTypeInteger}, nil
+	case ipType:
+		// The type alone doesn't tell us v4 vs v6; ipv4 is the common case.
+		return Property{Type: PropertyTypeString, Format: "ipv4"}, nil
+	}
+
+	switch ft.Kind() {
+	case reflect.String:
+		return Property{Type: PropertyTypeString}, nil
+	case reflect.Bool:
+		return Property{Type: PropertyTypeBoolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Property{Type: PropertyTypeInteger}, nil
+	case reflect.Float32, reflect.Float64:
+		return Property{Type: PropertyTypeNumber}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.fromType(ft.Elem())
+		if err != nil {
+			return Property{}, err
+		}
+		property := Property{Type: PropertyTypeArray, Items: &items}
+		if ft.Kind() == reflect.Array {
+			size := uint(ft.Len())
+			property.MinItems = &size
+			property.MaxItems = &size
+		}
+		return property, nil
+	case reflect.Map:
+		return Property{Type: PropertyTypeObject}, nil
+	case reflect.Struct:
+		return g.fromNestedStruct(ft)
+	default:
+		return Property{}, fmt.Errorf("unsupported kind %s", ft.Kind())
+	}
+}
+
+func (g *generator) fromNestedStruct(ft reflect.Type) (Property, error) {
+	// Anonymous struct types (ft.Name() == "") have no declared name, so
+	// they can't be tracked for cycles (Go has no way to write one
+	// referencing itself) or keyed by name. Under Generate (g.registry set)
+	// they're keyed below, after fromStruct, by a hash of their content;
+	// From keys them by refKey, which is empty for them.
+	named := ft.Name() != ""
+
+	key := refKey(ft)
+	if named && g.registry != nil {
+		// Generate wants a standard pkg.TypeName $defs key; From's own
+		// refKey-based ref format is unaffected since g.registry is nil
+		// whenever From is called directly.
+		key = defsKey(ft)
+	}
+	ref := g.refPrefix + key
+
+	if g.filter != nil && !g.filter(ref) {
+		if named {
+			if g.inFlight[key] {
+				return Property{}, fmt.Errorf(
+					"jsonschema: %s is self-referential and can't be fully inlined; use Generate's default $defs mode (WithRefs) instead of WithInline",
+					ft,
+				)
+			}
+			g.inFlight[key] = true
+			defer delete(g.inFlight, key)
+		}
+		return g.fromStruct(ft)
+	}
+
+	if named {
+		if g.inFlight[key] {
+			// ft is already being walked higher up the call stack (a cycle,
+			// e.g. a tree or linked-list type); emit the same $ref the
+			// in-progress call will register instead of recursing forever.
+			return Property{Ref: ref}, nil
+		}
+		g.inFlight[key] = true
+		defer delete(g.inFlight, key)
+	}
+
+	nested, err := g.fromStruct(ft)
+	if err != nil {
+		return Property{}, err
+	}
+
+	if g.registry != nil && !named {
+		// Two anonymous struct fields can't be told apart by name, only by
+		// shape: dedupe identical ones by content hash instead of minting
+		// named types colliding on the empty key. Named types are never
+		// deduped this way, even when two unrelated ones share a shape
+		// (e.g. two single-string-field structs) - they keep their own
+		// distinct $defs entries.
+		hash := contentHash(nested)
+		if existingKey, ok := g.registry.byHash[hash]; ok {
+			return Property{Ref: g.refPrefix + existingKey}, nil
+		}
+		key = anonDefsKey(hash)
+		ref = g.refPrefix + key
+		g.registry.byHash[hash] = key
+	}
+
+	if g.onNewProperty != nil {
+		g.onNewProperty(key, nested)
+	}
+	return Property{Ref: ref}, nil
+}
+
+// refKey builds From's ref/onNewProperty key, e.g.
+// "github.com/mjarkk/jsonschema".NestedStruct -> "Github.comMjarkkJsonschemaNestedStruct".
+// This is From's original, pre-Generate format, kept as-is for its existing
+// callback-based API; Generate uses defsKey instead for its $defs keys.
+func refKey(t reflect.Type) string {
+	var b strings.Builder
+	for _, segment := range strings.Split(t.PkgPath(), "/") {
+		if segment == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(segment[:1]))
+		b.WriteString(segment[1:])
+	}
+	b.WriteString(t.Name())
+	return b.String()
+}
+
+func validateRawJSON(kind string, values []json.RawMessage) {
+	for _, value := range values {
+		if !json.Valid(value) {
+			panic(fmt.Sprintf("jsonschema: invalid %s value: %s", kind, value))
+		}
+	}
+}
@@ -138,7 +138,8 @@ func TestFrom(t *testing.T) {
 			}{},
 			map[string]Property{
 				"A": {
-					Type: PropertyTypeString,
+					Type:   PropertyTypeString,
+					Format: "objectid",
 				},
 				"B": {},
 			},
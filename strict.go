@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WithStrict makes From (and Generate) emit a schema compatible with
+// OpenAI's structured-outputs dialect: every object gets
+// `additionalProperties: false`, every property is listed in `required`
+// (optional fields are modeled as `type: ["...", "null"]` instead of being
+// dropped from required), and generation fails with a descriptive error if
+// the value needs a keyword the dialect doesn't support (an open-ended
+// map or json.RawMessage field, which has no fixed property set to
+// enumerate).
+func WithStrict(strict bool) Option {
+	return func(o *genOptions) { o.strict = strict }
+}
+
+// makeStrict rewrites property in place so every one of the given field
+// names is required, marking the ones that weren't naturally required as
+// nullable instead of optional.
+func makeStrict(property *Property, names []string) {
+	required := map[string]bool{}
+	for _, name := range property.Required {
+		required[name] = true
+	}
+	for _, name := range names {
+		if !required[name] {
+			field := property.Properties[name]
+			field.Nullable = true
+			property.Properties[name] = field
+		}
+	}
+	property.Required = names
+	falseValue := false
+	property.AdditionalProperties = &falseValue
+}
+
+// checkStrictSupported rejects property trees that use keywords the OpenAI
+// structured-outputs dialect can't express, such as an open-ended object
+// (a Go map, or json.RawMessage) with no fixed property set.
+func checkStrictSupported(property Property) error {
+	if property.Ref != "" {
+		return nil
+	}
+	if property.Type == PropertyTypeObject && property.Properties == nil {
+		return fmt.Errorf("strict mode requires a fixed set of properties, got an open-ended object")
+	}
+	if property.Type == "" && property.Ref == "" {
+		return fmt.Errorf("strict mode requires a concrete type, got an untyped (arbitrary JSON) value")
+	}
+	for name, child := range property.Properties {
+		if err := checkStrictSupported(child); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+	if property.Items != nil {
+		if err := checkStrictSupported(*property.Items); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalStrict validates data against schema before decoding it into
+// out, so malformed LLM structured output is caught as a ValidationError
+// rather than silently producing a zero-valued out.
+func UnmarshalStrict(schema Property, data []byte, out any) error {
+	if errs := Validate(schema, data); len(errs) > 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = err.Error()
+		}
+		return fmt.Errorf("jsonschema: %d validation error(s): %s", len(errs), strings.Join(messages, "; "))
+	}
+	return json.Unmarshal(data, out)
+}
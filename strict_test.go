@@ -0,0 +1,97 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestFromWithStrict(t *testing.T) {
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		Title    string
+		Subtitle *string
+		Inner    Inner
+	}
+
+	property, err := From(
+		Outer{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return false }, // inline Inner so there's nothing left to check
+		nil,
+		WithStrict(true),
+	)
+	NoError(t, err)
+	Equal(t, []string{"Title", "Subtitle", "Inner"}, property.Required)
+	NotNil(t, property.AdditionalProperties)
+	False(t, *property.AdditionalProperties)
+
+	subtitle := property.Properties["Subtitle"]
+	True(t, subtitle.Nullable)
+
+	b, err := json.Marshal(subtitle)
+	NoError(t, err)
+	JSONEq(t, `{"type":["string","null"]}`, string(b))
+}
+
+func TestGenerateWithStrictNullableRef(t *testing.T) {
+	type Inner struct {
+		Name string
+	}
+	type Outer struct {
+		Sub *Inner
+	}
+
+	schema, err := Generate(Outer{}, WithStrict(true))
+	NoError(t, err)
+	Contains(t, schema.Property.Required, "Sub")
+
+	sub := schema.Property.Properties["Sub"]
+	True(t, sub.Nullable)
+	NotEmpty(t, sub.Ref)
+
+	b, err := json.Marshal(sub)
+	NoError(t, err)
+	JSONEq(t, `{"anyOf":[{"$ref":"`+sub.Ref+`"},{"type":"null"}]}`, string(b))
+
+	validator := NewValidatorFromSchema(schema)
+	Empty(t, validator.Validate([]byte(`{"Sub":null}`)))
+}
+
+func TestFromWithStrictRejectsOpenMap(t *testing.T) {
+	type WithMap struct {
+		Tags map[string]string
+	}
+
+	_, err := From(
+		WithMap{},
+		"#/testing/",
+		func(string, Property) {},
+		func(string) bool { return true },
+		nil,
+		WithStrict(true),
+	)
+	Error(t, err)
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	schema := Property{
+		Type:     PropertyTypeObject,
+		Required: []string{"name"},
+		Properties: map[string]Property{
+			"name": {Type: PropertyTypeString},
+		},
+	}
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	NoError(t, UnmarshalStrict(schema, []byte(`{"name":"bob"}`), &out))
+	Equal(t, "bob", out.Name)
+
+	Error(t, UnmarshalStrict(schema, []byte(`{}`), &out))
+}
@@ -0,0 +1,232 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// ValidationError describes a single schema violation found by Validate.
+type ValidationError struct {
+	// Path is a JSON Pointer (RFC 6901) to the offending value.
+	Path string
+	// Keyword is the JSON Schema keyword that failed, e.g. "required" or
+	// "minItems".
+	Keyword string
+	// Value is the offending value, or nil when none applies (e.g. a
+	// missing required property).
+	Value any
+	// Message is a human-readable description of the failure.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s (keyword %q)", e.Path, e.Message, e.Keyword)
+}
+
+// Validator is a schema compiled once and reused to validate many
+// documents against it.
+type Validator struct {
+	root Property
+	defs map[string]Property
+}
+
+// NewValidator compiles schema into a reusable Validator.
+func NewValidator(schema Property) *Validator {
+	return &Validator{root: schema}
+}
+
+// NewValidatorFromSchema compiles a Schema produced by Generate, resolving
+// `$ref`s against its `$defs`.
+func NewValidatorFromSchema(schema *Schema) *Validator {
+	return &Validator{root: schema.Property, defs: schema.Defs}
+}
+
+// Validate parses data as JSON and checks it against schema. It is a
+// shorthand for NewValidator(schema).Validate(data).
+func Validate(schema Property, data []byte) []ValidationError {
+	return NewValidator(schema).Validate(data)
+}
+
+// Validate parses data as JSON and reports every violation of v's schema.
+// A nil/empty result means data is valid.
+func (v *Validator) Validate(data []byte) []ValidationError {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []ValidationError{{Path: "", Keyword: "json", Message: "invalid JSON: " + err.Error()}}
+	}
+
+	var errs []ValidationError
+	v.validate("", v.root, value, &errs)
+	return errs
+}
+
+func (v *Validator) resolveRef(ref string) (Property, bool) {
+	key := ref
+	if idx := strings.LastIndex(ref, "/"); idx >= 0 {
+		key = ref[idx+1:]
+	}
+	property, ok := v.defs[key]
+	return property, ok
+}
+
+func (v *Validator) validate(path string, schema Property, value any, errs *[]ValidationError) {
+	if schema.Nullable && value == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		resolved, ok := v.resolveRef(schema.Ref)
+		if !ok {
+			*errs = append(*errs, ValidationError{Path: path, Keyword: "$ref", Value: schema.Ref, Message: "unresolved $ref"})
+			return
+		}
+		v.validate(path, resolved, value, errs)
+		return
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "enum", Value: value, Message: "value is not one of the allowed enum values"})
+		return
+	}
+
+	switch schema.Type {
+	case PropertyTypeObject:
+		v.validateObject(path, schema, value, errs)
+	case PropertyTypeArray:
+		v.validateArray(path, schema, value, errs)
+	case PropertyTypeString:
+		v.validateString(path, schema, value, errs)
+	case PropertyTypeInteger, PropertyTypeNumber:
+		v.validateNumber(path, schema, value, errs)
+	case PropertyTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected a boolean"})
+		}
+	}
+}
+
+func (v *Validator) validateObject(path string, schema Property, value any, errs *[]ValidationError) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected an object"})
+		return
+	}
+
+	// RFC 6901 escaping of a reference token: "~" -> "~0", "/" -> "~1".
+	escape := strings.NewReplacer("~", "~0", "/", "~1").Replace
+
+	for _, required := range schema.Required {
+		if _, ok := obj[required]; !ok {
+			*errs = append(*errs, ValidationError{Path: path + "/" + escape(required), Keyword: "required", Message: "missing required property"})
+		}
+	}
+
+	for key, propertyValue := range obj {
+		propertySchema, ok := schema.Properties[key]
+		if !ok {
+			continue
+		}
+		v.validate(path+"/"+escape(key), propertySchema, propertyValue, errs)
+	}
+}
+
+func (v *Validator) validateArray(path string, schema Property, value any, errs *[]ValidationError) {
+	arr, ok := value.([]any)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected an array"})
+		return
+	}
+
+	if schema.MinItems != nil && uint(len(arr)) < *schema.MinItems {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "minItems", Value: len(arr), Message: fmt.Sprintf("expected at least %d items", *schema.MinItems)})
+	}
+	if schema.MaxItems != nil && uint(len(arr)) > *schema.MaxItems {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "maxItems", Value: len(arr), Message: fmt.Sprintf("expected at most %d items", *schema.MaxItems)})
+	}
+	if schema.UniqueItems {
+		seen := map[string]bool{}
+		for _, item := range arr {
+			b, _ := json.Marshal(item)
+			if seen[string(b)] {
+				*errs = append(*errs, ValidationError{Path: path, Keyword: "uniqueItems", Value: item, Message: "items must be unique"})
+				break
+			}
+			seen[string(b)] = true
+		}
+	}
+	if schema.Items != nil {
+		for i, item := range arr {
+			v.validate(fmt.Sprintf("%s/%d", path, i), *schema.Items, item, errs)
+		}
+	}
+}
+
+func (v *Validator) validateString(path string, schema Property, value any, errs *[]ValidationError) {
+	s, ok := value.(string)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected a string"})
+		return
+	}
+	if schema.Pattern != "" {
+		re, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			*errs = append(*errs, ValidationError{Path: path, Keyword: "pattern", Value: schema.Pattern, Message: "invalid pattern: " + err.Error()})
+		} else if !re.MatchString(s) {
+			*errs = append(*errs, ValidationError{Path: path, Keyword: "pattern", Value: s, Message: fmt.Sprintf("value does not match pattern %q", schema.Pattern)})
+		}
+	}
+	if schema.Format != "" {
+		if check, ok := formatCheckers[schema.Format]; ok && !check(s) {
+			*errs = append(*errs, ValidationError{Path: path, Keyword: "format", Value: s, Message: fmt.Sprintf("value is not a valid %q", schema.Format)})
+		}
+	}
+}
+
+func (v *Validator) validateNumber(path string, schema Property, value any, errs *[]ValidationError) {
+	n, ok := value.(float64)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected a number"})
+		return
+	}
+	if schema.Type == PropertyTypeInteger && n != math.Trunc(n) {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "type", Value: value, Message: "expected an integer"})
+	}
+	if schema.Minimum != nil && n < *schema.Minimum {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "minimum", Value: value, Message: fmt.Sprintf("value must be >= %v", *schema.Minimum)})
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		*errs = append(*errs, ValidationError{Path: path, Keyword: "maximum", Value: value, Message: fmt.Sprintf("value must be <= %v", *schema.Maximum)})
+	}
+}
+
+func enumContains(enum []json.RawMessage, value any) bool {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return false
+	}
+	normalizedEncoded, err := json.Marshal(normalized)
+	if err != nil {
+		return false
+	}
+	for _, raw := range enum {
+		var candidate any
+		if err := json.Unmarshal(raw, &candidate); err != nil {
+			continue
+		}
+		candidateEncoded, err := json.Marshal(candidate)
+		if err != nil {
+			continue
+		}
+		if string(candidateEncoded) == string(normalizedEncoded) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package jsonschema
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	minimum := 0.0
+	maximum := 120.0
+	minItems := uint(1)
+
+	schema := Property{
+		Type:     PropertyTypeObject,
+		Required: []string{"name"},
+		Properties: map[string]Property{
+			"name": {Type: PropertyTypeString},
+			"age":  {Type: PropertyTypeInteger, Minimum: &minimum, Maximum: &maximum},
+			"tags": {Type: PropertyTypeArray, MinItems: &minItems, UniqueItems: true, Items: &Property{Type: PropertyTypeString}},
+		},
+	}
+
+	scenarios := []struct {
+		name     string
+		data     string
+		keywords []string
+	}{
+		{"valid", `{"name":"bob","age":30,"tags":["a","b"]}`, nil},
+		{"missing required", `{"age":30}`, []string{"required"}},
+		{"wrong type", `{"name":1}`, []string{"type"}},
+		{"out of range", `{"name":"bob","age":200}`, []string{"maximum"}},
+		{"too few items", `{"name":"bob","tags":[]}`, []string{"minItems"}},
+		{"duplicate items", `{"name":"bob","tags":["a","a"]}`, []string{"uniqueItems"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			errs := Validate(schema, []byte(s.data))
+			if len(s.keywords) == 0 {
+				Empty(t, errs)
+				return
+			}
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Keyword)
+			}
+			for _, keyword := range s.keywords {
+				Contains(t, got, keyword)
+			}
+		})
+	}
+}
+
+func TestValidateRef(t *testing.T) {
+	schema := &Schema{
+		Property: Property{
+			Type: PropertyTypeObject,
+			Properties: map[string]Property{
+				"address": {Ref: "#/$defs/Address"},
+			},
+		},
+		Defs: map[string]Property{
+			"Address": {
+				Type:     PropertyTypeObject,
+				Required: []string{"city"},
+				Properties: map[string]Property{
+					"city": {Type: PropertyTypeString},
+				},
+			},
+		},
+	}
+
+	validator := NewValidatorFromSchema(schema)
+	Empty(t, validator.Validate([]byte(`{"address":{"city":"Rotterdam"}}`)))
+
+	errs := validator.Validate([]byte(`{"address":{}}`))
+	Len(t, errs, 1)
+	Equal(t, "required", errs[0].Keyword)
+}